@@ -3,6 +3,10 @@ package token
 // This is a simple package for go that generates randomized base62 encoded tokens based on a single integer.
 // It's ideal for shorturl services or for semi-secured randomized api primary keys.
 //
+// `New` and `NewWith` draw from `math/rand` and are predictable, not suitable for anything where an
+// attacker guessing a token has real consequences (e.g. an unguessable URL or an API key). Use
+// `NewSecure` for those: it draws from `crypto/rand` and rejection-samples to avoid modulo bias.
+//
 // How it Works
 //
 // `Token` is an alias for `uint64`.
@@ -16,16 +20,13 @@ package token
 // **IMPORTANT:** Remember to always check for collisions when adding randomized tokens to a database
 
 import (
-	"bytes"
 	"math"
 	"math/rand"
+	"sync"
 	"time"
 )
 
 const (
-	// Base62 is a string respresentation of every possible base62 character
-	Base62 = "0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
-
 	// MaxTokenLength is the largest possible character length of a token
 	MaxTokenLength = 10
 
@@ -36,109 +37,144 @@ const (
 	DefaultTokenLength = 9
 )
 
-var (
-	base62Len = uint64(len(Base62))
-)
+// seedOnce lazily seeds the package-level `math/rand` source the first time `New` or `NewWith`
+// actually needs it, instead of unconditionally seeding it in an `init()` that runs even for
+// callers who only ever use `NewSecure`
+var seedOnce sync.Once
 
-// init initializes the random number generator
-func init() {
-	rand.Seed(time.Now().UTC().UnixNano())
+func seed() {
+	seedOnce.Do(func() {
+		rand.Seed(time.Now().UTC().UnixNano())
+	})
 }
 
 // Token is an alias of an uint64 that is marshalled into a base62 encoded token
 type Token uint64
 
-// Encode encodes the token into a base62 string
+// Encode encodes the token into a `Base62` string
 func (t Token) Encode() string {
-	bs, _ := t.MarshalText()
-	return string(bs)
+	return t.EncodeWith(Base62)
 }
 
-// UnmarshalText implements the `encoding.TextUnmarshaler` interface
-func (t *Token) UnmarshalText(data []byte) error {
-
-	number := uint64(0)
-	idx := 0.0
-	chars := []byte(Base62)
+// EncodeWith encodes the token into a string using `a` instead of `Base62`
+func (t Token) EncodeWith(a *Alphabet) string {
+	return encode(uint64(t), a, 0)
+}
 
-	charsLength := float64(len(chars))
-	tokenLength := float64(len(data))
+// encode is the shared implementation behind `Token.EncodeWith` and `SortableToken.Encode`. It
+// base62 (or `a`) encodes `number`, left-padding the result with `a`'s zero-character up to
+// `minWidth` so that, e.g., a `SortableToken`'s encoded length is always the same regardless of
+// how small its underlying value is
+func encode(number uint64, a *Alphabet, minWidth int) string {
+	radix := uint64(len(a.chars))
 
-	if tokenLength > MaxTokenLength {
-		return ErrTokenTooBig
-	} else if tokenLength < MinTokenLength {
-		return ErrTokenTooSmall
+	var chars []byte
+	if number == 0 {
+		chars = []byte{a.chars[0]}
+	} else {
+		for number > 0 {
+			chars = append(chars, a.chars[number%radix])
+			number /= radix
+		}
+		for i, j := 0, len(chars)-1; i < j; i, j = i+1, j-1 {
+			chars[i], chars[j] = chars[j], chars[i]
+		}
 	}
 
-	for _, c := range data {
-		power := tokenLength - (idx + 1)
-		index := bytes.IndexByte(chars, c)
-		if index < 0 {
-			return ErrInvalidCharacter
-		}
-		number += uint64(index) * uint64(math.Pow(charsLength, power))
-		idx++
+	for len(chars) < minWidth {
+		chars = append([]byte{a.chars[0]}, chars...)
 	}
 
-	// the token was successfully decoded
-	*t = Token(number)
+	return string(chars)
+}
+
+// UnmarshalText implements the `encoding.TextUnmarshaler` interface
+func (t *Token) UnmarshalText(data []byte) error {
+	decoded, err := DecodeWith(string(data), Base62)
+	if err != nil {
+		return err
+	}
+	*t = decoded
 	return nil
 }
 
 // MarshalText implements the `encoding.TextMarsheler` interface
 func (t Token) MarshalText() ([]byte, error) {
-	number := uint64(t)
-	var chars []byte
-
-	if number == 0 {
-		return chars, nil
-	}
-
-	for number > 0 {
-		result := number / base62Len
-		remainder := number % base62Len
-		chars = append(chars, Base62[remainder])
-		number = result
-	}
-
-	for i, j := 0, len(chars)-1; i < j; i, j = i+1, j-1 {
-		chars[i], chars[j] = chars[j], chars[i]
-	}
-
-	return chars, nil
+	return []byte(t.Encode()), nil
 }
 
 // New returns a `Base62` encoded `Token` of *up to* `DefaultTokenLength`
 // if you pass in a `tokenLength` between `MinTokenLength` and `MaxTokenLength` this will return
 // a `Token` of *up to* that length instead if you pass in a `tokenLength` that is out of range it will panic
 func New(tokenLength ...int) Token {
+	length := DefaultTokenLength
+	if tokenLength != nil {
+		length = tokenLength[0]
+	}
+	return NewWith(Base62, length)
+}
+
+// NewWith returns a `Token` of *up to* `length` characters when encoded with `a`. It panics if
+// `length` is outside of `MinTokenLength` and `MaxTokenLength`
+func NewWith(a *Alphabet, length int) Token {
 
 	// calculate the max hash int based on the token length
 	var max uint64
-	if tokenLength == nil {
-		max = maxHashInt(DefaultTokenLength)
-	} else if tl := tokenLength[0]; tl < MinTokenLength {
+	if length < MinTokenLength {
 		panic(ErrTokenTooSmall)
-	} else if tl > MaxTokenLength {
+	} else if length > MaxTokenLength {
 		panic(ErrTokenTooBig)
 	} else {
-		max = maxHashInt(tl)
+		max = maxHashInt(uint64(len(a.chars)), length)
 	}
 
 	// generate a psuedo random token
+	seed()
 	number := uint64(rand.Int63n(int64(max & math.MaxInt64)))
 
 	return Token(number)
 }
 
-// Decode returns a token from a 1-12 character base62 encoded string
+// Decode returns a `Token` from a `Base62` encoded string
 func Decode(token string) (Token, error) {
-	var t Token
-	err := (&t).UnmarshalText([]byte(token))
-	return t, err
+	return DecodeWith(token, Base62)
+}
+
+// DecodeWith returns a `Token` from a string encoded with `a` instead of `Base62`. It decodes
+// with a Horner scheme integer accumulator (`number = number*radix + index`) rather than
+// `math.Pow`, which is both faster and avoids the silent rounding errors `math.Pow` can produce
+// near the top of the `uint64` range. It returns `ErrTokenOverflow` if the decoded value would
+// not fit in a `uint64` — with `Base62` and `MaxTokenLength` this can never actually happen
+// (62^10 is nowhere close to `math.MaxUint64`), but a custom `Alphabet` of ~65 or more
+// characters can overflow within `MaxTokenLength` characters
+func DecodeWith(token string, a *Alphabet) (Token, error) {
+
+	tokenLength := len(token)
+
+	if tokenLength > MaxTokenLength {
+		return Token(0), ErrTokenTooBig
+	} else if tokenLength < MinTokenLength {
+		return Token(0), ErrTokenTooSmall
+	}
+
+	radix := uint64(len(a.chars))
+
+	var number uint64
+	for i := 0; i < len(token); i++ {
+		index := a.decode[token[i]]
+		if index < 0 {
+			return Token(0), ErrInvalidCharacter
+		}
+		if number > (math.MaxUint64-uint64(index))/radix {
+			return Token(0), ErrTokenOverflow
+		}
+		number = number*radix + uint64(index)
+	}
+
+	return Token(number), nil
 }
 
-// maxHashInt returns the largest possible int that will yeild a base62 encoded token of the specified length
-func maxHashInt(length int) uint64 {
-	return uint64(math.Max(0, math.Min(math.MaxUint64, math.Pow(float64(base62Len), float64(length)))))
+// maxHashInt returns the largest possible int that will yeild a `radix`-encoded token of the specified length
+func maxHashInt(radix uint64, length int) uint64 {
+	return uint64(math.Max(0, math.Min(math.MaxUint64, math.Pow(float64(radix), float64(length)))))
 }
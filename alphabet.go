@@ -0,0 +1,86 @@
+package token
+
+// An `Alphabet` is the ordered set of characters a `Token` is encoded and decoded with.
+// `Base62` is the `Alphabet` the package-level `Encode`/`Decode`/`New` functions use, but any
+// `Alphabet` built with `NewAlphabet` can be used instead via `Token.EncodeWith`, `DecodeWith`,
+// and `NewWith`.
+
+import (
+	"math"
+	"unicode/utf8"
+)
+
+// Alphabet is the ordered set of characters a `Token` is encoded and decoded with
+type Alphabet struct {
+	chars  string
+	decode [256]int8
+}
+
+// NewAlphabet returns an `Alphabet` that encodes and decodes with `chars`. It returns
+// `ErrInvalidAlphabet` if `chars` is empty, contains a non-ASCII character, or is too long to
+// be indexed by a single digit, and `ErrDuplicateCharacter` if `chars` repeats a character
+func NewAlphabet(chars string) (*Alphabet, error) {
+	if len(chars) == 0 || len(chars) > math.MaxInt8 {
+		return nil, ErrInvalidAlphabet
+	}
+
+	a := &Alphabet{chars: chars}
+	for i := range a.decode {
+		a.decode[i] = -1
+	}
+
+	for i := 0; i < len(chars); i++ {
+		c := chars[i]
+		if c >= utf8.RuneSelf {
+			return nil, ErrInvalidAlphabet
+		}
+		if a.decode[c] != -1 {
+			return nil, ErrDuplicateCharacter
+		}
+		a.decode[c] = int8(i)
+	}
+
+	return a, nil
+}
+
+// mustNewAlphabet is like `NewAlphabet`, but panics instead of returning an error. It is only
+// used to build the package-level `Alphabet` presets below, whose `chars` are known-good
+func mustNewAlphabet(chars string) *Alphabet {
+	a, err := NewAlphabet(chars)
+	if err != nil {
+		panic(err)
+	}
+	return a
+}
+
+// withCaseInsensitiveDecode returns `a` after also mapping the opposite case of every ASCII
+// letter in its `chars` to the same decoded index. `Encode`/`EncodeWith` are unaffected and
+// keep producing `a.chars`'s original case; only decoding becomes case-insensitive
+func (a *Alphabet) withCaseInsensitiveDecode() *Alphabet {
+	for i := 0; i < len(a.chars); i++ {
+		c := a.chars[i]
+		switch {
+		case c >= 'a' && c <= 'z':
+			a.decode[c-('a'-'A')] = int8(i)
+		case c >= 'A' && c <= 'Z':
+			a.decode[c+('a'-'A')] = int8(i)
+		}
+	}
+	return a
+}
+
+var (
+	// Base62 is the `Alphabet` used by the package-level `Encode`, `Decode`, and `New` functions
+	Base62 = mustNewAlphabet("0123456789abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ")
+
+	// Base58BitcoinAlphabet is Bitcoin's base58 alphabet. It drops the characters that are
+	// easily confused when handwritten or read aloud: `0`, `O`, `I`, and `l`
+	Base58BitcoinAlphabet = mustNewAlphabet("123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz")
+
+	// CrockfordBase32 is Crockford's base32 alphabet, designed for tokens people transcribe by
+	// hand: it excludes `I`, `L`, `O`, and `U`, and decodes case-insensitively
+	CrockfordBase32 = mustNewAlphabet("0123456789ABCDEFGHJKMNPQRSTVWXYZ").withCaseInsensitiveDecode()
+
+	// URLSafeBase64 is the URL and filename safe base64 alphabet from RFC 4648 section 5
+	URLSafeBase64 = mustNewAlphabet("ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789-_")
+)
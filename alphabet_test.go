@@ -0,0 +1,74 @@
+package token
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/marksalpeter/sugar"
+)
+
+func TestAlphabet(t *testing.T) {
+	s := sugar.New(t)
+
+	s.Assert("NewAlphabet rejects an empty alphabet", func(log sugar.Log) bool {
+		if _, err := NewAlphabet(""); err != ErrInvalidAlphabet {
+			log("expected ErrInvalidAlphabet, got %v", err)
+			return false
+		}
+		return true
+	})
+
+	s.Assert("NewAlphabet rejects a duplicate character", func(log sugar.Log) bool {
+		if _, err := NewAlphabet("aab"); err != ErrDuplicateCharacter {
+			log("expected ErrDuplicateCharacter, got %v", err)
+			return false
+		}
+		return true
+	})
+
+	s.Assert("NewAlphabet rejects a non-ASCII character", func(log sugar.Log) bool {
+		if _, err := NewAlphabet("abπ"); err != ErrInvalidAlphabet {
+			log("expected ErrInvalidAlphabet, got %v", err)
+			return false
+		}
+		return true
+	})
+
+	s.Assert("EncodeWith and DecodeWith are consistant for every preset Alphabet", func(log sugar.Log) bool {
+		for _, alphabet := range []*Alphabet{Base62, Base58BitcoinAlphabet, CrockfordBase32, URLSafeBase64} {
+			original := NewWith(alphabet, DefaultTokenLength)
+			decoded, err := DecodeWith(original.EncodeWith(alphabet), alphabet)
+			if err != nil {
+				log(err)
+				return false
+			}
+			if decoded != original {
+				log("%d != %d", decoded, original)
+				return false
+			}
+		}
+		return true
+	})
+
+	s.Assert("CrockfordBase32 decodes case-insensitively", func(log sugar.Log) bool {
+		original := NewWith(CrockfordBase32, DefaultTokenLength)
+		encoded := original.EncodeWith(CrockfordBase32)
+
+		lower, err := DecodeWith(strings.ToLower(encoded), CrockfordBase32)
+		if err != nil {
+			log(err)
+			return false
+		}
+		return log.Compare(lower, original)
+	})
+
+	s.Assert("Encode and New remain backwards compatible with Base62", func(log sugar.Log) bool {
+		original := New()
+		decoded, err := Decode(original.Encode())
+		if err != nil {
+			log(err)
+			return false
+		}
+		return log.Compare(decoded, original)
+	})
+}
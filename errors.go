@@ -0,0 +1,30 @@
+package token
+
+import "errors"
+
+var (
+	// ErrTokenTooSmall is the error returned or panic'd when a base62 token is smaller than `MinTokenLength`
+	ErrTokenTooSmall = errors.New("the base62 token is smaller than MinTokenLength")
+
+	// ErrTokenTooBig is the error returned or panic'd when a base62 token is larger than `MaxTokenLength`
+	ErrTokenTooBig = errors.New("the base62 token is larger than MaxTokenLength")
+
+	// ErrInvalidCharacter is the error returned or panic'd when a non `Base62` string is being parsed
+	ErrInvalidCharacter = errors.New("there was a non base62 character in the token")
+
+	// ErrInvalidSignature is the error returned when a `SignedToken` fails HMAC verification
+	ErrInvalidSignature = errors.New("the token signature is invalid")
+
+	// ErrTokenOverflow is the error returned when a token decodes to a value larger than
+	// `math.MaxUint64`. With `Base62` this is unreachable within `MaxTokenLength` characters; it
+	// exists for wider custom `Alphabet`s (see `DecodeWith`)
+	ErrTokenOverflow = errors.New("the token overflows a uint64")
+
+	// ErrInvalidAlphabet is the error returned by `NewAlphabet` when its characters are empty,
+	// contain a non-ASCII character, or are too long to be indexed by a single digit
+	ErrInvalidAlphabet = errors.New("the alphabet is empty, non-ASCII, or too long")
+
+	// ErrDuplicateCharacter is the error returned by `NewAlphabet` when its characters contain
+	// a duplicate
+	ErrDuplicateCharacter = errors.New("the alphabet contains a duplicate character")
+)
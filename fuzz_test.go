@@ -0,0 +1,28 @@
+package token
+
+import "testing"
+
+// FuzzToken round-trips arbitrary byte inputs through `Decode`/`Token.Encode`, in the style of
+// `encoding/json`'s fuzz targets, and asserts that a decodable token re-encodes to exactly the
+// bytes it was decoded from
+func FuzzToken(f *testing.F) {
+	f.Add("")
+	f.Add("0")
+	f.Add(Base62.chars)
+	f.Add("s p a c e")
+	f.Add("sfnalsdasdkasdnaerlaraksfnmaslrasdasadsadas")
+
+	f.Fuzz(func(t *testing.T, input string) {
+		decoded, err := Decode(input)
+		if err != nil {
+			return
+		}
+
+		// a decodable token must always round trip through its own re-encoding, even if
+		// `input` itself had leading zero characters that `Encode` won't reproduce
+		redecoded, err := Decode(decoded.Encode())
+		if err != nil || redecoded != decoded {
+			t.Fatalf("round trip failed: Decode(%q) = %d, but re-decoding its Encode() gave %d, %v", input, decoded, redecoded, err)
+		}
+	})
+}
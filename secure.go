@@ -0,0 +1,51 @@
+package token
+
+// `NewSecure` generates a `Token` from a cryptographically secure random source instead of the
+// `math/rand` source `New` uses. It exists for the "semi-secured randomized api primary keys"
+// use case this package advertises, where `New`'s predictable, time-seeded source is not
+// appropriate.
+
+import (
+	cryptorand "crypto/rand"
+	"encoding/binary"
+	"io"
+)
+
+// Reader is the source `NewSecure` draws randomness from. It defaults to `crypto/rand.Reader`
+// and is only a `var` so tests can inject a deterministic `io.Reader`
+var Reader io.Reader = cryptorand.Reader
+
+// NewSecure returns a `Base62` encoded `Token` of *up to* `DefaultTokenLength` drawn from
+// `Reader`, rejection-sampling its output so that every value up to the maximum for the token
+// length is equally likely. If you pass in a `tokenLength` between `MinTokenLength` and
+// `MaxTokenLength` this will return a `Token` of *up to* that length instead. It returns an
+// error if `tokenLength` is out of range or if `Reader` fails
+func NewSecure(tokenLength ...int) (Token, error) {
+	length := DefaultTokenLength
+	if tokenLength != nil {
+		length = tokenLength[0]
+	}
+
+	if length < MinTokenLength {
+		return Token(0), ErrTokenTooSmall
+	} else if length > MaxTokenLength {
+		return Token(0), ErrTokenTooBig
+	}
+
+	max := maxHashInt(uint64(len(Base62.chars)), length)
+
+	// reject draws in the top, incomplete multiple of `max` so that `draw % max` doesn't
+	// favor smaller remainders, i.e. doesn't introduce modulo bias
+	const maxUint64 = ^uint64(0)
+	limit := maxUint64 - maxUint64%max
+
+	for {
+		var draw uint64
+		if err := binary.Read(Reader, binary.BigEndian, &draw); err != nil {
+			return Token(0), err
+		}
+		if draw < limit {
+			return Token(draw % max), nil
+		}
+	}
+}
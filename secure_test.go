@@ -0,0 +1,67 @@
+package token
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/marksalpeter/sugar"
+)
+
+func TestNewSecure(t *testing.T) {
+	s := sugar.New(t)
+
+	s.Assert("NewSecure returns a Token within range of a deterministic Reader", func(log sugar.Log) bool {
+		old := Reader
+		defer func() { Reader = old }()
+
+		var buf bytes.Buffer
+		binary.Write(&buf, binary.BigEndian, uint64(42))
+		Reader = &buf
+
+		token, err := NewSecure(MinTokenLength)
+		if err != nil {
+			log(err)
+			return false
+		}
+		return log.Compare(token, Token(42%uint64(len(Base62.chars))))
+	})
+
+	s.Assert("NewSecure returns an error when Reader fails", func(log sugar.Log) bool {
+		old := Reader
+		defer func() { Reader = old }()
+		Reader = bytes.NewReader(nil)
+
+		if _, err := NewSecure(); err == nil {
+			log("expected an error when the Reader is exhausted")
+			return false
+		}
+		return true
+	})
+
+	s.Assert("NewSecure returns an error when tokenLength is out of range", func(log sugar.Log) bool {
+		if _, err := NewSecure(MinTokenLength - 1); err != ErrTokenTooSmall {
+			log("expected ErrTokenTooSmall, got %v", err)
+			return false
+		}
+		if _, err := NewSecure(MaxTokenLength + 1); err != ErrTokenTooBig {
+			log("expected ErrTokenTooBig, got %v", err)
+			return false
+		}
+		return true
+	})
+
+	s.Assert("NewSecure drawn from crypto/rand.Reader round trips through Encode/Decode", func(log sugar.Log) bool {
+		original, err := NewSecure()
+		if err != nil {
+			log(err)
+			return false
+		}
+		decoded, err := Decode(original.Encode())
+		if err != nil {
+			log(err)
+			return false
+		}
+		return log.Compare(decoded, original)
+	})
+}
@@ -0,0 +1,141 @@
+package token
+
+// A `Signer` wraps a `Token` with a keyed HMAC-SHA256 message authentication code so that a
+// server can hand a token to an untrusted client and later verify it was not tampered with,
+// without needing a database lookup. This is a common requirement for URL-shortener and
+// API-key style tokens.
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"strings"
+)
+
+const (
+	// signatureSeparator separates the base62 encoded token from its base62 encoded signature
+	// in the wire format produced by `Signer.Sign`
+	signatureSeparator = "."
+
+	// signatureLength is the number of bytes the HMAC-SHA256 digest is truncated to before
+	// being base62 encoded and appended to the token
+	signatureLength = 8
+)
+
+// Signer signs and verifies `Token`s with a keyed HMAC-SHA256 message authentication code
+type Signer struct {
+	key []byte
+}
+
+// NewSigner returns a `Signer` that signs and verifies tokens with the given key
+func NewSigner(key []byte) *Signer {
+	return &Signer{key: key}
+}
+
+// Sign returns the base62 encoded token followed by a `signatureSeparator` and the base62
+// encoded, truncated HMAC-SHA256 signature of the token
+func (s *Signer) Sign(t Token) string {
+	encoded := t.Encode()
+	return encoded + signatureSeparator + encodeSignature(s.mac(encoded))
+}
+
+// Verify parses a string produced by `Sign` and returns the `Token` it contains if, and only
+// if, its signature is valid. It uses `hmac.Equal` to compare signatures in constant time and
+// returns `ErrInvalidSignature` — and only `ErrInvalidSignature` — if the token or its signature
+// is missing, malformed, or does not match, so callers never learn *why* a signed string was
+// rejected
+func (s *Signer) Verify(signed string) (Token, error) {
+	parts := strings.SplitN(signed, signatureSeparator, 2)
+	if len(parts) != 2 {
+		return Token(0), ErrInvalidSignature
+	}
+
+	encoded, signature := parts[0], parts[1]
+
+	t, err := Decode(encoded)
+	if err != nil {
+		return Token(0), ErrInvalidSignature
+	}
+
+	expected, ok := decodeSignature(signature)
+	if !ok || !hmac.Equal(expected, s.mac(encoded)) {
+		return Token(0), ErrInvalidSignature
+	}
+
+	return t, nil
+}
+
+// mac returns the first `signatureLength` bytes of the HMAC-SHA256 digest of `data`
+func (s *Signer) mac(data string) []byte {
+	h := hmac.New(sha256.New, s.key)
+	h.Write([]byte(data))
+	return h.Sum(nil)[:signatureLength]
+}
+
+// encodeSignature base62 encodes a `signatureLength`-byte MAC using the same Horner-scheme
+// `encode` helper `Token.EncodeWith` and `SortableToken.Encode` use. Unlike `Token.Encode` this
+// is not bounded by `MaxTokenLength`, since the signature is a fixed-size quantity independent
+// of the token it authenticates
+func encodeSignature(mac []byte) string {
+	var number uint64
+	for _, b := range mac {
+		number = number<<8 | uint64(b)
+	}
+	return encode(number, Base62, 0)
+}
+
+// decodeSignature parses a string produced by `encodeSignature` back into the original
+// `signatureLength` bytes. It returns `false` if the string contains a character outside of
+// `Base62`
+func decodeSignature(s string) ([]byte, bool) {
+	radix := uint64(len(Base62.chars))
+	var number uint64
+	for _, c := range []byte(s) {
+		index := Base62.decode[c]
+		if index < 0 {
+			return nil, false
+		}
+		number = number*radix + uint64(index)
+	}
+
+	mac := make([]byte, signatureLength)
+	for i := signatureLength - 1; i >= 0; i-- {
+		mac[i] = byte(number)
+		number >>= 8
+	}
+	return mac, true
+}
+
+// SignedToken is a `Token` that marshals to and from its signed, base62 encoded wire format so
+// it slots into JSON and XML the same way `Token` does today. Its zero value is not usable;
+// construct one with `NewSignedToken`
+type SignedToken struct {
+	Token
+	signer *Signer
+}
+
+// NewSignedToken wraps `t` so that it marshals through `signer`
+func NewSignedToken(signer *Signer, t Token) SignedToken {
+	return SignedToken{Token: t, signer: signer}
+}
+
+// MarshalText implements the `encoding.TextMarshaler` interface
+func (s SignedToken) MarshalText() ([]byte, error) {
+	if s.signer == nil {
+		return nil, ErrInvalidSignature
+	}
+	return []byte(s.signer.Sign(s.Token)), nil
+}
+
+// UnmarshalText implements the `encoding.TextUnmarshaler` interface. `s.signer` must already
+// be set, typically by first calling `NewSignedToken`
+func (s *SignedToken) UnmarshalText(data []byte) error {
+	if s.signer == nil {
+		return ErrInvalidSignature
+	}
+	t, err := s.signer.Verify(string(data))
+	if err != nil {
+		return err
+	}
+	s.Token = t
+	return nil
+}
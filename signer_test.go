@@ -0,0 +1,92 @@
+package token
+
+import (
+	"testing"
+
+	"github.com/marksalpeter/sugar"
+)
+
+func TestSigner(t *testing.T) {
+	s := sugar.New(t)
+
+	s.Assert("Sign and Verify are consistant", func(log sugar.Log) bool {
+		signer := NewSigner([]byte("secret"))
+		original := New()
+		signed := signer.Sign(original)
+		verified, err := signer.Verify(signed)
+		if err != nil {
+			log(err)
+			return false
+		}
+		return log.Compare(original, verified)
+	})
+
+	s.Assert("Verify returns ErrInvalidSignature when the signature is missing or tampered with", func(log sugar.Log) bool {
+		signer := NewSigner([]byte("secret"))
+		signed := signer.Sign(New())
+
+		if _, err := signer.Verify(signed + "tampered"); err != ErrInvalidSignature {
+			log("tampering with the signature did not return ErrInvalidSignature")
+			return false
+		}
+
+		if _, err := signer.Verify(signed[:len(signed)-2]); err != ErrInvalidSignature {
+			log("a truncated signature did not return ErrInvalidSignature")
+			return false
+		}
+
+		if _, err := signer.Verify("no-separator-here"); err != ErrInvalidSignature {
+			log("a missing separator did not return ErrInvalidSignature")
+			return false
+		}
+
+		return true
+	})
+
+	s.Assert("Verify returns ErrInvalidSignature, not a Decode error, when the token half is malformed", func(log sugar.Log) bool {
+		signer := NewSigner([]byte("secret"))
+
+		// a token portion that is too big to be a valid Base62 token would otherwise surface
+		// Decode's own ErrTokenTooBig
+		if _, err := signer.Verify("zzzzzzzzzzzzzz.abc"); err != ErrInvalidSignature {
+			log("expected ErrInvalidSignature for an oversized token, got %v", err)
+			return false
+		}
+
+		// a token portion with an invalid character would otherwise surface ErrInvalidCharacter
+		if _, err := signer.Verify("s p a c e.abc"); err != ErrInvalidSignature {
+			log("expected ErrInvalidSignature for an invalid character, got %v", err)
+			return false
+		}
+
+		return true
+	})
+
+	s.Assert("Verify rejects a token signed with a different key", func(log sugar.Log) bool {
+		signed := NewSigner([]byte("secret")).Sign(New())
+		if _, err := NewSigner([]byte("different")).Verify(signed); err != ErrInvalidSignature {
+			log("a token signed with a different key was not rejected")
+			return false
+		}
+		return true
+	})
+
+	s.Assert("SignedToken implements encoding.TextMarshaler and encoding.TextUnmarshaler", func(log sugar.Log) bool {
+		signer := NewSigner([]byte("secret"))
+		original := NewSignedToken(signer, New())
+
+		text, err := original.MarshalText()
+		if err != nil {
+			log(err)
+			return false
+		}
+
+		unmarshaled := NewSignedToken(signer, Token(0))
+		if err := unmarshaled.UnmarshalText(text); err != nil {
+			log(err)
+			return false
+		}
+
+		return log.Compare(original.Token, unmarshaled.Token)
+	})
+}
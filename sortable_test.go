@@ -0,0 +1,77 @@
+package token
+
+import (
+	"testing"
+	"time"
+
+	"github.com/marksalpeter/sugar"
+)
+
+func TestSortableToken(t *testing.T) {
+	s := sugar.New(t)
+
+	s.Assert("Encode and UnmarshalText are consistant", func(log sugar.Log) bool {
+		original := NewSortable()
+		var decoded SortableToken
+		if err := decoded.UnmarshalText([]byte(original.Encode())); err != nil {
+			log(err)
+			return false
+		}
+		return log.Compare(decoded, original)
+	})
+
+	s.Assert("Encode always returns MaxTokenLength characters", func(log sugar.Log) bool {
+		for i := 0; i < 100; i++ {
+			if encoded := NewSortable().Encode(); len(encoded) != MaxTokenLength {
+				log("len(%s) == %d, want %d", encoded, len(encoded), MaxTokenLength)
+				return false
+			}
+		}
+		return true
+	})
+
+	s.Assert("sorting encoded SortableTokens lexically sorts them chronologically", func(log sugar.Log) bool {
+		config := SortableConfig{TimeBits: DefaultSortableConfig.TimeBits, RandomBits: DefaultSortableConfig.RandomBits, Epoch: DefaultSortableConfig.Epoch}
+		first := config.New()
+		time.Sleep(2 * time.Millisecond)
+		second := config.New()
+
+		if first.Encode() >= second.Encode() {
+			log("%s >= %s, want first < second", first.Encode(), second.Encode())
+			return false
+		}
+		return true
+	})
+
+	s.Assert("Time returns the instant the SortableToken was created", func(log sugar.Log) bool {
+		before := time.Now()
+		token := NewSortable()
+		after := time.Now()
+
+		tokenTime := token.Time()
+		if tokenTime.Before(before.Add(-time.Millisecond)) || tokenTime.After(after.Add(time.Millisecond)) {
+			log("%s not within [%s, %s]", tokenTime, before, after)
+			return false
+		}
+		return true
+	})
+
+	s.Assert("SortableConfig.New panics when TimeBits + RandomBits exceeds maxSortableBits", func(log sugar.Log) bool {
+		isPaniced := false
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					isPaniced = true
+				}
+			}()
+			// 42 + 22 = 64 bits, 5 over maxSortableBits, so this must panic rather than silently
+			// wrap or truncate
+			config := SortableConfig{TimeBits: 42, RandomBits: 22, Epoch: DefaultSortableConfig.Epoch}
+			config.New()
+		}()
+		if !isPaniced {
+			log("did not panic")
+		}
+		return isPaniced
+	})
+}
@@ -0,0 +1,132 @@
+package token
+
+import (
+	"bytes"
+	"io"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/marksalpeter/sugar"
+)
+
+func TestStream(t *testing.T) {
+	s := sugar.New(t)
+
+	s.Assert("Encoder and Decoder round trip a stream of tokens", func(log sugar.Log) bool {
+		var buf bytes.Buffer
+		originals := make([]Token, 100)
+		for i := range originals {
+			originals[i] = New()
+		}
+
+		encoder := NewEncoder(&buf)
+		for _, original := range originals {
+			if err := encoder.Encode(original); err != nil {
+				log(err)
+				return false
+			}
+		}
+		if err := encoder.Flush(); err != nil {
+			log(err)
+			return false
+		}
+
+		decoder := NewDecoder(&buf)
+		for _, original := range originals {
+			var decoded Token
+			if err := decoder.Decode(&decoded); err != nil {
+				log(err)
+				return false
+			}
+			if decoded != original {
+				log("%s != %s", decoded, original)
+				return false
+			}
+		}
+
+		return true
+	})
+
+	s.Assert("Decoder returns io.EOF once the stream is exhausted", func(log sugar.Log) bool {
+		decoder := NewDecoder(bytes.NewBufferString(New().Encode()))
+		var t Token
+		if err := decoder.Decode(&t); err != nil {
+			log(err)
+			return false
+		}
+		if err := decoder.Decode(&t); err != io.EOF {
+			log("expected io.EOF, got %v", err)
+			return false
+		}
+		return true
+	})
+
+	s.Assert("Decoder returns ErrTokenTooBig for a token longer than MaxTokenLength", func(log sugar.Log) bool {
+		decoder := NewDecoder(bytes.NewBufferString(strings.Repeat("1", MaxTokenLength+1)))
+		var t Token
+		if err := decoder.Decode(&t); err != ErrTokenTooBig {
+			log("expected ErrTokenTooBig, got %v", err)
+			return false
+		}
+		return true
+	})
+
+	s.Assert("Encoder returns ErrTokenTooBig instead of panicking on a Token needing more than MaxTokenLength digits", func(log sugar.Log) bool {
+		var buf bytes.Buffer
+		encoder := NewEncoder(&buf)
+		if err := encoder.Encode(Token(math.MaxUint64)); err != ErrTokenTooBig {
+			log("expected ErrTokenTooBig, got %v", err)
+			return false
+		}
+		return true
+	})
+}
+
+func BenchmarkEncoderEncode(b *testing.B) {
+	var buf bytes.Buffer
+	encoder := NewEncoder(&buf)
+	tok := New()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		buf.Reset()
+		encoder.Encode(tok)
+	}
+}
+
+func BenchmarkMarshalTextLoop(b *testing.B) {
+	tok := New()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		tok.MarshalText()
+	}
+}
+
+// BenchmarkDecoderDecode and BenchmarkDecodeLoop, below, deliberately read from a `bytes.Buffer`
+// already holding every token in memory, so they measure CPU cost only, not the `Read` syscalls
+// `Decoder` is actually built to amortize -- see the doc comment on `Decoder.Decode`. Expect
+// them to be roughly on par, not one beating the other 2x
+func BenchmarkDecoderDecode(b *testing.B) {
+	line := New().Encode() + string(DefaultDelimiter)
+
+	var buf bytes.Buffer
+	for i := 0; i < b.N; i++ {
+		buf.WriteString(line)
+	}
+	decoder := NewDecoder(&buf)
+	var decoded Token
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		decoder.Decode(&decoded)
+	}
+}
+
+func BenchmarkDecodeLoop(b *testing.B) {
+	encoded := New().Encode()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		Decode(encoded)
+	}
+}
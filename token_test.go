@@ -3,6 +3,7 @@ package token
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/marksalpeter/sugar"
@@ -56,10 +57,32 @@ func TestToken(t *testing.T) {
 		return true
 	})
 
-	s.Assert("maxHashInt(tokenLength int) returns tokens of the correct length", func(log sugar.Log) bool {
+	s.Assert("DecodeWith returns ErrTokenOverflow for a wide custom Alphabet", func(log sugar.Log) bool {
+		// Base62 can never overflow within MaxTokenLength (62^10 is nowhere close to
+		// math.MaxUint64), so exercise the overflow path with a ~94 character alphabet instead
+		var chars []byte
+		for c := byte('!'); c <= '~'; c++ {
+			chars = append(chars, c)
+		}
+		alphabet, err := NewAlphabet(string(chars))
+		if err != nil {
+			log(err)
+			return false
+		}
+
+		overflowing := strings.Repeat(string(chars[len(chars)-1]), MaxTokenLength)
+		if _, err := DecodeWith(overflowing, alphabet); err != ErrTokenOverflow {
+			log("expected ErrTokenOverflow, got %v", err)
+			return false
+		}
+		return true
+	})
+
+	s.Assert("maxHashInt(radix uint64, tokenLength int) returns tokens of the correct length", func(log sugar.Log) bool {
 		for i := MinTokenLength; i <= MaxTokenLength; i++ {
-			min := Token(maxHashInt(i - 1)).Encode()
-			max := Token(maxHashInt(i) - 1).Encode()
+			radix := uint64(len(Base62.chars))
+			min := Token(maxHashInt(radix, i-1)).Encode()
+			max := Token(maxHashInt(radix, i) - 1).Encode()
 			if len(max) != i {
 				log("failed on max -> %d != len(%s)", i, max)
 				return false